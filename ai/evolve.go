@@ -0,0 +1,249 @@
+package ai
+
+import (
+	"math"
+	"math/rand"
+)
+
+// simPoint mirrors the board coordinates used by the main package. It is
+// kept private and duplicated here rather than imported so the ai package
+// can simulate games headlessly without depending on the pixelgl-backed
+// main package.
+type simPoint struct {
+	x, y int64
+}
+
+// simSnake is the minimal state needed to play out a game for fitness
+// evaluation; it moves and wraps around the edges like game.Snake under
+// game.WrapRules, but running into its own tail ends the game instead of
+// truncating the tail.
+type simSnake struct {
+	size   int64
+	head   simPoint
+	tail   []simPoint
+	food   simPoint
+	growth int64
+	rng    *rand.Rand
+}
+
+func newSimSnake(size int64, rng *rand.Rand) *simSnake {
+	s := &simSnake{
+		size: size,
+		head: simPoint{size / 2, size / 2},
+		rng:  rng,
+	}
+	s.placeFood()
+	return s
+}
+
+func (s *simSnake) occupied(p simPoint) bool {
+	if p == s.head {
+		return true
+	}
+	for _, t := range s.tail {
+		if t == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *simSnake) placeFood() {
+	for {
+		p := simPoint{s.rng.Int63n(s.size), s.rng.Int63n(s.size)}
+		if !s.occupied(p) {
+			s.food = p
+			return
+		}
+	}
+}
+
+// SimConfig controls a headless evaluation run.
+type SimConfig struct {
+	BoardSize           int64
+	MaxSteps            int
+	StepsSinceFoodLimit int
+	SurvivalWeight      float64
+	StarvationPenalty   float64
+}
+
+// DefaultSimConfig returns reasonable defaults for a 10x10 board.
+func DefaultSimConfig() SimConfig {
+	return SimConfig{
+		BoardSize:           10,
+		MaxSteps:            500,
+		StepsSinceFoodLimit: 100,
+		SurvivalWeight:      0.01,
+		StarvationPenalty:   0.05,
+	}
+}
+
+// inputs builds the network input vector: relative food vector, distance
+// to each wall, and a danger flag for each of the four adjacent cells.
+func (s *simSnake) inputs() [InputSize]float64 {
+	var in [InputSize]float64
+	in[0] = float64(s.food.x - s.head.x)
+	in[1] = float64(s.food.y - s.head.y)
+	in[2] = float64(s.head.x)          // distance to left wall
+	in[3] = float64(s.size - s.head.x) // distance to right wall
+	in[4] = float64(s.head.y)          // distance to top wall
+	in[5] = float64(s.size - s.head.y) // distance to bottom wall
+	deltas := [4]simPoint{{0, -1}, {0, 1}, {-1, 0}, {1, 0}}
+	for i, d := range deltas {
+		next := simPoint{s.head.x + d.x, s.head.y + d.y}
+		if s.occupied(next) {
+			in[6+i] = 1
+		}
+	}
+	return in
+}
+
+// Simulate runs one headless game with the given network and returns its
+// fitness: length + eps*stepsSurvived - penalty*stepsSinceLastFood.
+func Simulate(net Network, seed int64, cfg SimConfig) float64 {
+	rng := rand.New(rand.NewSource(seed))
+	s := newSimSnake(cfg.BoardSize, rng)
+	direction := 0 // up
+	stepsSinceFood := 0
+	steps := 0
+	for ; steps < cfg.MaxSteps; steps++ {
+		choice := net.Decide(s.inputs())
+		// Disallow reversing directly into the tail.
+		if !(choice == 0 && direction == 1) && !(choice == 1 && direction == 0) &&
+			!(choice == 2 && direction == 3) && !(choice == 3 && direction == 2) {
+			direction = choice
+		}
+		var dx, dy int64
+		switch direction {
+		case 0:
+			dy = -1
+		case 1:
+			dy = 1
+		case 2:
+			dx = -1
+		case 3:
+			dx = 1
+		}
+		delta := int64(1)
+		if s.growth > 0 {
+			delta = 0
+			s.growth--
+		}
+		if delta > int64(len(s.tail)) {
+			delta = int64(len(s.tail))
+		}
+		s.tail = append([]simPoint{s.head}, s.tail[:int64(len(s.tail))-delta]...)
+		s.head.x += dx
+		s.head.y += dy
+		if s.head.x < 0 {
+			s.head.x = s.size - 1
+		}
+		if s.head.x >= s.size {
+			s.head.x = 0
+		}
+		if s.head.y < 0 {
+			s.head.y = s.size - 1
+		}
+		if s.head.y >= s.size {
+			s.head.y = 0
+		}
+		for _, t := range s.tail {
+			if t == s.head {
+				steps++
+				goto dead
+			}
+		}
+		if s.head == s.food {
+			s.growth++
+			stepsSinceFood = 0
+			s.placeFood()
+		} else {
+			stepsSinceFood++
+			if stepsSinceFood > cfg.StepsSinceFoodLimit {
+				steps++
+				goto dead
+			}
+		}
+	}
+dead:
+	length := float64(len(s.tail) + 1)
+	return length + cfg.SurvivalWeight*float64(steps) - cfg.StarvationPenalty*float64(stepsSinceFood)
+}
+
+// EvolveConfig controls a training run.
+type EvolveConfig struct {
+	Population     int
+	Generations    int
+	Seed           int64
+	InitialSigma   float64
+	SigmaDecay     float64
+	TournamentSize int
+	Sim            SimConfig
+	// OnGeneration, if set, is called after each generation with the best
+	// genome found so far, so callers can checkpoint progress to disk.
+	OnGeneration func(generation int, best Genome, bestFitness float64)
+}
+
+// DefaultEvolveConfig returns reasonable defaults for a population of 50.
+func DefaultEvolveConfig() EvolveConfig {
+	return EvolveConfig{
+		Population:     50,
+		Generations:    100,
+		Seed:           1,
+		InitialSigma:   0.5,
+		SigmaDecay:     0.98,
+		TournamentSize: 3,
+		Sim:            DefaultSimConfig(),
+	}
+}
+
+func tournamentSelect(rng *rand.Rand, pop []Genome, fitness []float64, size int) Genome {
+	bestIdx := rng.Intn(len(pop))
+	for i := 1; i < size; i++ {
+		candidate := rng.Intn(len(pop))
+		if fitness[candidate] > fitness[bestIdx] {
+			bestIdx = candidate
+		}
+	}
+	return pop[bestIdx]
+}
+
+// Evolve runs the genetic algorithm and returns the best genome found.
+func Evolve(cfg EvolveConfig) (Genome, float64) {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	pop := make([]Genome, cfg.Population)
+	for i := range pop {
+		pop[i] = NewGenome(rng)
+	}
+
+	var bestGenome Genome
+	bestFitness := math.Inf(-1)
+	sigma := cfg.InitialSigma
+
+	for gen := 0; gen < cfg.Generations; gen++ {
+		fitness := make([]float64, cfg.Population)
+		for i, g := range pop {
+			fitness[i] = Simulate(NewNetwork(g), cfg.Seed, cfg.Sim)
+			if fitness[i] > bestFitness {
+				bestFitness = fitness[i]
+				bestGenome = g
+			}
+		}
+
+		next := make([]Genome, cfg.Population)
+		for i := range next {
+			parentA := tournamentSelect(rng, pop, fitness, cfg.TournamentSize)
+			parentB := tournamentSelect(rng, pop, fitness, cfg.TournamentSize)
+			child := Crossover(rng, parentA, parentB)
+			next[i] = Mutate(rng, child, sigma)
+		}
+		pop = next
+		sigma *= cfg.SigmaDecay
+
+		if cfg.OnGeneration != nil {
+			cfg.OnGeneration(gen, bestGenome, bestFitness)
+		}
+	}
+
+	return bestGenome, bestFitness
+}
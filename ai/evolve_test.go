@@ -0,0 +1,37 @@
+package ai
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestSimulateFirstStepNoUnderflow guards against a regression where the
+// first step of every simulation panicked: newSimSnake starts with an
+// empty tail, so growth==0 gave delta==1 and len(tail)-delta==-1.
+func TestSimulateFirstStepNoUnderflow(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	net := NewNetwork(NewGenome(rng))
+	cfg := DefaultSimConfig()
+	cfg.MaxSteps = 1
+
+	fitness := Simulate(net, 1, cfg)
+	if math.IsNaN(fitness) || math.IsInf(fitness, 0) {
+		t.Fatalf("Simulate returned non-finite fitness: %v", fitness)
+	}
+}
+
+// TestSimulateDeterministic checks that the same genome and seed produce
+// the same fitness, since reproducibility is what makes fitness a valid
+// selection signal.
+func TestSimulateDeterministic(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	net := NewNetwork(NewGenome(rng))
+	cfg := DefaultSimConfig()
+
+	first := Simulate(net, 42, cfg)
+	second := Simulate(net, 42, cfg)
+	if first != second {
+		t.Fatalf("Simulate(seed=42) not deterministic: %v != %v", first, second)
+	}
+}
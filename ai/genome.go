@@ -0,0 +1,149 @@
+// Package ai implements a small neuroevolution autopilot for the snake.
+//
+// A genome is the flattened weight vector of a 2-layer feed-forward
+// network. Inputs are the relative food vector, the distance to each of
+// the four walls and a danger flag for each adjacent cell; outputs are
+// one logit per direction (up, down, left, right).
+package ai
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"os"
+)
+
+const (
+	InputSize  = 10
+	HiddenSize = 12
+	OutputSize = 4
+)
+
+// weightCount is the number of weights (including biases) a genome needs
+// to fully describe a network of the sizes above.
+var weightCount = InputSize*HiddenSize + HiddenSize + HiddenSize*OutputSize + OutputSize
+
+// Genome is the flattened weight vector of a Network.
+type Genome struct {
+	Weights []float64 `json:"weights"`
+}
+
+// NewGenome builds a genome with random weights in [-1, 1].
+func NewGenome(rng *rand.Rand) Genome {
+	w := make([]float64, weightCount)
+	for i := range w {
+		w[i] = rng.Float64()*2 - 1
+	}
+	return Genome{Weights: w}
+}
+
+// SaveGenome writes a genome to disk as JSON.
+func SaveGenome(path string, g Genome) error {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadGenome reads a genome previously written by SaveGenome.
+func LoadGenome(path string) (Genome, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Genome{}, err
+	}
+	var g Genome
+	if err := json.Unmarshal(data, &g); err != nil {
+		return Genome{}, err
+	}
+	return g, nil
+}
+
+// Network is a 2-layer MLP built from a Genome's weights.
+type Network struct {
+	w1 [][]float64 // HiddenSize x InputSize
+	b1 []float64
+	w2 [][]float64 // OutputSize x HiddenSize
+	b2 []float64
+}
+
+// NewNetwork unpacks a genome's flattened weights into a Network.
+func NewNetwork(g Genome) Network {
+	n := Network{
+		w1: make([][]float64, HiddenSize),
+		b1: make([]float64, HiddenSize),
+		w2: make([][]float64, OutputSize),
+		b2: make([]float64, OutputSize),
+	}
+	i := 0
+	for h := 0; h < HiddenSize; h++ {
+		n.w1[h] = make([]float64, InputSize)
+		for in := 0; in < InputSize; in++ {
+			n.w1[h][in] = g.Weights[i]
+			i++
+		}
+	}
+	for h := 0; h < HiddenSize; h++ {
+		n.b1[h] = g.Weights[i]
+		i++
+	}
+	for o := 0; o < OutputSize; o++ {
+		n.w2[o] = make([]float64, HiddenSize)
+		for h := 0; h < HiddenSize; h++ {
+			n.w2[o][h] = g.Weights[i]
+			i++
+		}
+	}
+	for o := 0; o < OutputSize; o++ {
+		n.b2[o] = g.Weights[i]
+		i++
+	}
+	return n
+}
+
+// Decide runs the network forward and returns the index of the highest
+// logit (0=up, 1=down, 2=left, 3=right).
+func (n Network) Decide(inputs [InputSize]float64) int {
+	hidden := make([]float64, HiddenSize)
+	for h := 0; h < HiddenSize; h++ {
+		sum := n.b1[h]
+		for in := 0; in < InputSize; in++ {
+			sum += n.w1[h][in] * inputs[in]
+		}
+		hidden[h] = math.Tanh(sum)
+	}
+	best, bestScore := 0, math.Inf(-1)
+	for o := 0; o < OutputSize; o++ {
+		sum := n.b2[o]
+		for h := 0; h < HiddenSize; h++ {
+			sum += n.w2[o][h] * hidden[h]
+		}
+		if sum > bestScore {
+			best, bestScore = o, sum
+		}
+	}
+	return best
+}
+
+// Crossover combines two parent genomes by picking each weight uniformly
+// at random from one of the two parents.
+func Crossover(rng *rand.Rand, a, b Genome) Genome {
+	child := Genome{Weights: make([]float64, len(a.Weights))}
+	for i := range child.Weights {
+		if rng.Intn(2) == 0 {
+			child.Weights[i] = a.Weights[i]
+		} else {
+			child.Weights[i] = b.Weights[i]
+		}
+	}
+	return child
+}
+
+// Mutate perturbs each weight with Gaussian noise scaled by sigma.
+func Mutate(rng *rand.Rand, g Genome, sigma float64) Genome {
+	child := Genome{Weights: make([]float64, len(g.Weights))}
+	for i, w := range g.Weights {
+		child.Weights[i] = w + rng.NormFloat64()*sigma
+	}
+	return child
+}
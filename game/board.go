@@ -0,0 +1,70 @@
+package game
+
+import "math/rand"
+
+// Board tracks which cells of a Width x Height grid are occupied, backed
+// by a Quadtree so collision checks and food placement stay fast even
+// when the board is large and mostly full.
+type Board struct {
+	Width  int64
+	Height int64
+	tree   *Quadtree
+}
+
+// NewBoard creates an empty board of the given size.
+func NewBoard(width, height int64) *Board {
+	return &Board{
+		Width:  width,
+		Height: height,
+		tree:   NewQuadtree(Rect{0, 0, width, height}),
+	}
+}
+
+// Occupied reports whether p is occupied.
+func (b *Board) Occupied(p Point) bool {
+	return b.tree.Contains(p)
+}
+
+// Mark occupies p.
+func (b *Board) Mark(p Point) {
+	b.tree.Insert(p)
+}
+
+// Unmark frees p.
+func (b *Board) Unmark(p Point) {
+	b.tree.Remove(p)
+}
+
+// RandomFreePoint picks a uniformly random unoccupied cell, retrying
+// against the quadtree until one is found. Each attempt is an O(log n)
+// query rather than a scan of every occupied cell, so this stays fast
+// even when the board is nearly full.
+func (b *Board) RandomFreePoint(rng *rand.Rand) Point {
+	for {
+		p := Point{rng.Int63n(b.Width), rng.Int63n(b.Height)}
+		if !b.Occupied(p) {
+			return p
+		}
+	}
+}
+
+// RandomFreePointFrom picks a uniformly random point from candidates that
+// isn't occupied, treating exclude (the snake's current head) as free.
+// It's used when food must spawn within a restricted zone
+// (Rules.FoodZones) rather than anywhere on the board, where a single
+// unlucky candidate could otherwise never be free: a one-cell zone is
+// occupied by the head the instant the snake eats the food sitting on
+// it. Falls back to any candidate if the zone has no free cell at all,
+// rather than retrying forever.
+func (b *Board) RandomFreePointFrom(rng *rand.Rand, candidates []Point, exclude Point) Point {
+	free := make([]Point, 0, len(candidates))
+	for _, p := range candidates {
+		if p == exclude || !b.Occupied(p) {
+			free = append(free, p)
+		}
+	}
+	if len(free) == 0 {
+		free = candidates
+	}
+	return free[rng.Intn(len(free))]
+}
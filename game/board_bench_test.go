@@ -0,0 +1,58 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// linearRandomFreePoint is the old approach generateNextPoint used: pick a
+// random point and recheck it against every occupied cell, retrying on
+// collision. It's kept here only to benchmark against Board.RandomFreePoint.
+func linearRandomFreePoint(rng *rand.Rand, size int64, occupied []Point) Point {
+	for {
+		p := Point{rng.Int63n(size), rng.Int63n(size)}
+		collided := false
+		for _, o := range occupied {
+			if o == p {
+				collided = true
+				break
+			}
+		}
+		if !collided {
+			return p
+		}
+	}
+}
+
+func fillBoard(size int64, fraction float64, rng *rand.Rand) (*Board, []Point) {
+	board := NewBoard(size, size)
+	var occupied []Point
+	total := int64(float64(size*size) * fraction)
+	for int64(len(occupied)) < total {
+		p := Point{rng.Int63n(size), rng.Int63n(size)}
+		if board.Occupied(p) {
+			continue
+		}
+		board.Mark(p)
+		occupied = append(occupied, p)
+	}
+	return board, occupied
+}
+
+func BenchmarkRandomFreePoint_Quadtree(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	board, _ := fillBoard(200, 0.9, rng)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.RandomFreePoint(rng)
+	}
+}
+
+func BenchmarkRandomFreePoint_Linear(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	_, occupied := fillBoard(200, 0.9, rng)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linearRandomFreePoint(rng, 200, occupied)
+	}
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// LoadLevel reads an ASCII maze file and returns the Rules it describes
+// plus the snake's starting point. Each line is a row of the board: '#'
+// is a wall, '.' is empty floor, 'S' marks the single snake start, and
+// 'F' marks floor food is allowed to spawn on (it's otherwise treated the
+// same as '.'). The board width is the length of the longest line.
+func LoadLevel(path string) (Rules, Point, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Rules{}, Point{}, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return Rules{}, Point{}, err
+	}
+
+	rules := Rules{Height: int64(len(lines)), FoodCount: 1, GrowthPerFood: 1}
+	start := Point{X: -1, Y: -1}
+	for y, line := range lines {
+		if int64(len(line)) > rules.Width {
+			rules.Width = int64(len(line))
+		}
+		for x, c := range line {
+			p := Point{X: int64(x), Y: int64(y)}
+			switch c {
+			case '#':
+				rules.Obstacles = append(rules.Obstacles, p)
+			case 'S':
+				start = p
+			case '.':
+				// walkable floor, nothing to record.
+			case 'F':
+				rules.FoodZones = append(rules.FoodZones, p)
+			default:
+				return Rules{}, Point{}, fmt.Errorf("level %s: unknown symbol %q at (%d,%d)", path, c, x, y)
+			}
+		}
+	}
+	if start == (Point{X: -1, Y: -1}) {
+		return Rules{}, Point{}, fmt.Errorf("level %s: missing snake start (S)", path)
+	}
+	return rules, start, nil
+}
@@ -0,0 +1,26 @@
+// Package game holds the board and snake model shared by the playable
+// game and the headless tooling around it (benchmarks).
+package game
+
+// Point is a single board cell.
+type Point struct {
+	X int64
+	Y int64
+}
+
+// Rect is an axis-aligned bounding box used by the Quadtree, expressed as
+// an origin plus width/height.
+type Rect struct {
+	X, Y, W, H int64
+}
+
+// Contains reports whether p falls within r.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.X && p.X < r.X+r.W && p.Y >= r.Y && p.Y < r.Y+r.H
+}
+
+// Intersects reports whether r and other overlap.
+func (r Rect) Intersects(other Rect) bool {
+	return r.X < other.X+other.W && r.X+r.W > other.X &&
+		r.Y < other.Y+other.H && r.Y+r.H > other.Y
+}
@@ -0,0 +1,114 @@
+package game
+
+// quadtreeCapacity is how many points a leaf node holds before it splits
+// into four children.
+const quadtreeCapacity = 4
+
+// Quadtree is an AABB-based spatial index of occupied board cells. It
+// replaces the linear scans over the snake's tail that checkCollisions
+// and food placement used to do, so both stay fast as the board and the
+// snake grow.
+type Quadtree struct {
+	boundary Rect
+	points   []Point
+
+	divided   bool
+	northeast *Quadtree
+	northwest *Quadtree
+	southeast *Quadtree
+	southwest *Quadtree
+}
+
+// NewQuadtree creates an empty quadtree covering boundary.
+func NewQuadtree(boundary Rect) *Quadtree {
+	return &Quadtree{boundary: boundary}
+}
+
+// Insert adds p to the tree. It returns false if p falls outside the
+// tree's boundary.
+func (q *Quadtree) Insert(p Point) bool {
+	if !q.boundary.Contains(p) {
+		return false
+	}
+	if !q.divided && len(q.points) < quadtreeCapacity {
+		q.points = append(q.points, p)
+		return true
+	}
+	if !q.divided {
+		q.subdivide()
+	}
+	switch {
+	case q.northeast.Insert(p):
+	case q.northwest.Insert(p):
+	case q.southeast.Insert(p):
+	case q.southwest.Insert(p):
+	default:
+		return false
+	}
+	return true
+}
+
+// Remove deletes p from the tree, if present.
+func (q *Quadtree) Remove(p Point) bool {
+	if !q.boundary.Contains(p) {
+		return false
+	}
+	for i, existing := range q.points {
+		if existing == p {
+			q.points = append(q.points[:i], q.points[i+1:]...)
+			return true
+		}
+	}
+	if !q.divided {
+		return false
+	}
+	return q.northeast.Remove(p) || q.northwest.Remove(p) ||
+		q.southeast.Remove(p) || q.southwest.Remove(p)
+}
+
+// Contains reports whether p is occupied.
+func (q *Quadtree) Contains(p Point) bool {
+	if !q.boundary.Contains(p) {
+		return false
+	}
+	for _, existing := range q.points {
+		if existing == p {
+			return true
+		}
+	}
+	if !q.divided {
+		return false
+	}
+	return q.northeast.Contains(p) || q.northwest.Contains(p) ||
+		q.southeast.Contains(p) || q.southwest.Contains(p)
+}
+
+// Query appends every occupied point inside rng to out and returns the
+// extended slice.
+func (q *Quadtree) Query(rng Rect, out []Point) []Point {
+	if !q.boundary.Intersects(rng) {
+		return out
+	}
+	for _, p := range q.points {
+		if rng.Contains(p) {
+			out = append(out, p)
+		}
+	}
+	if !q.divided {
+		return out
+	}
+	out = q.northeast.Query(rng, out)
+	out = q.northwest.Query(rng, out)
+	out = q.southeast.Query(rng, out)
+	out = q.southwest.Query(rng, out)
+	return out
+}
+
+func (q *Quadtree) subdivide() {
+	x, y, w, h := q.boundary.X, q.boundary.Y, q.boundary.W/2, q.boundary.H/2
+	q.northwest = NewQuadtree(Rect{x, y, w, h})
+	q.northeast = NewQuadtree(Rect{x + w, y, q.boundary.W - w, h})
+	q.southwest = NewQuadtree(Rect{x, y + h, w, q.boundary.H - h})
+	q.southeast = NewQuadtree(Rect{x + w, y + h, q.boundary.W - w, q.boundary.H - h})
+	q.divided = true
+}
@@ -0,0 +1,30 @@
+package game
+
+// Rules configures the board shape and food behavior for one game, so the
+// same Board/Snake machinery can drive the original wrap-around board, a
+// walled "classic" board, or a maze loaded from a level file.
+type Rules struct {
+	Width         int64
+	Height        int64
+	WrapEdges     bool
+	Obstacles     []Point
+	FoodCount     int
+	GrowthPerFood int64
+	// FoodZones restricts food spawns to this set of cells (see
+	// LoadLevel's 'F' symbol). A nil/empty FoodZones means food may
+	// spawn anywhere unoccupied on the board.
+	FoodZones []Point
+}
+
+// WrapRules is the original wrap-around board: the snake reappears on the
+// opposite edge instead of dying, and a self-collision truncates the tail
+// rather than ending the game.
+func WrapRules(width, height int64) Rules {
+	return Rules{Width: width, Height: height, WrapEdges: true, FoodCount: 1, GrowthPerFood: 1}
+}
+
+// ClassicRules is a walled width x height board: running into an edge, an
+// obstacle, or the snake's own tail ends the game.
+func ClassicRules(width, height int64) Rules {
+	return Rules{Width: width, Height: height, FoodCount: 1, GrowthPerFood: 1}
+}
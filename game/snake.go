@@ -0,0 +1,178 @@
+package game
+
+import "math/rand"
+
+// Snake is the full game state for one snake living on a Board under a set
+// of Rules. Direction and NextDirection use the same 'u'/'d'/'l'/'r'
+// encoding the original single-file version used.
+type Snake struct {
+	Board         *Board
+	Rules         Rules
+	Head          Point
+	Tail          []Point
+	FoodPoints    []Point
+	Growth        int64
+	Direction     byte
+	NextDirection byte
+	// Dead is set once the snake runs into an edge, obstacle, or its own
+	// tail on a board where Rules.WrapEdges is false.
+	Dead bool
+
+	rng *rand.Rand
+}
+
+// NewSnake places a snake with the given head and tail on board under
+// rules. Obstacle and tail cells are marked occupied on the board; the
+// head is tracked separately and left unmarked, so CheckCollisions can
+// tell a head-into-body hit from the snake simply following itself.
+func NewSnake(board *Board, rules Rules, head Point, tail []Point, rng *rand.Rand) *Snake {
+	for _, o := range rules.Obstacles {
+		board.Mark(o)
+	}
+	s := &Snake{
+		Board:         board,
+		Rules:         rules,
+		Head:          head,
+		Tail:          append([]Point{}, tail...),
+		Direction:     'u',
+		NextDirection: 'u',
+		rng:           rng,
+	}
+	for _, p := range s.Tail {
+		s.Board.Mark(p)
+	}
+	return s
+}
+
+// Move advances the snake by one step. On a wrap-edges board it reappears
+// on the opposite edge; otherwise running off the board kills it.
+func (s *Snake) Move() {
+	// If we have to grow, do not remove last point of tail.
+	delta := int64(1)
+	if s.Growth > 0 {
+		delta = 0
+		s.Growth--
+	}
+	if delta > int64(len(s.Tail)) {
+		delta = int64(len(s.Tail))
+	}
+	var dropped Point
+	hasDropped := delta == 1 && int64(len(s.Tail)) > 0
+	if hasDropped {
+		dropped = s.Tail[int64(len(s.Tail))-1]
+	}
+	// Remove last point of tail and add new point where the head is pointing before movement.
+	s.Tail = append([]Point{s.Head}, s.Tail[:int64(len(s.Tail))-delta]...)
+	s.Board.Mark(s.Head)
+	if hasDropped {
+		s.Board.Unmark(dropped)
+	}
+
+	s.Direction = s.NextDirection
+	var dX, dY int64
+	switch s.Direction {
+	case 'u':
+		dY = -1
+	case 'd':
+		dY = 1
+	case 'l':
+		dX = -1
+	case 'r':
+		dX = 1
+	}
+	s.Head.Y += dY
+	s.Head.X += dX
+	if s.Rules.WrapEdges {
+		if s.Head.Y >= s.Board.Height {
+			s.Head.Y = 0
+		}
+		if s.Head.Y < 0 {
+			s.Head.Y = s.Board.Height - 1
+		}
+		if s.Head.X >= s.Board.Width {
+			s.Head.X = 0
+		}
+		if s.Head.X < 0 {
+			s.Head.X = s.Board.Width - 1
+		}
+	} else if s.Head.X < 0 || s.Head.X >= s.Board.Width || s.Head.Y < 0 || s.Head.Y >= s.Board.Height {
+		s.Dead = true
+	}
+}
+
+// GenerateFood tops FoodPoints up to Rules.FoodCount (at least one) with
+// new positions that don't collide with each other. Without food zones
+// they also avoid the snake; within Rules.FoodZones the head's cell counts
+// as free and a fully occupied zone falls back to any zone cell (see
+// Board.RandomFreePointFrom). Board's quadtree already keeps each attempt
+// at O(log n).
+func (s *Snake) GenerateFood() {
+	count := s.Rules.FoodCount
+	if count <= 0 {
+		count = 1
+	}
+	for len(s.FoodPoints) < count {
+		var p Point
+		if len(s.Rules.FoodZones) > 0 {
+			p = s.Board.RandomFreePointFrom(s.rng, s.Rules.FoodZones, s.Head)
+		} else {
+			p = s.Board.RandomFreePoint(s.rng)
+			if p == s.Head {
+				continue
+			}
+		}
+		duplicate := false
+		for _, food := range s.FoodPoints {
+			if food == p {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		s.FoodPoints = append(s.FoodPoints, p)
+	}
+}
+
+// CheckPoint grows the snake and rolls a new food position when the head
+// reaches one of the current FoodPoints.
+func (s *Snake) CheckPoint() {
+	for i, food := range s.FoodPoints {
+		if s.Head == food {
+			growth := s.Rules.GrowthPerFood
+			if growth <= 0 {
+				growth = 1
+			}
+			s.Growth += growth
+			s.FoodPoints = append(s.FoodPoints[:i], s.FoodPoints[i+1:]...)
+			break
+		}
+	}
+	s.GenerateFood()
+}
+
+// CheckCollisions handles the head running into something after Move. On
+// a wrap-edges board this mirrors the original behaviour of truncating
+// the tail from the point of collision rather than ending the game; on a
+// walled board (obstacles, classic mode) any collision kills the snake.
+// The common, non-colliding case is a single O(log n) quadtree lookup
+// instead of a scan over the whole tail.
+func (s *Snake) CheckCollisions() {
+	if s.Dead || !s.Board.Occupied(s.Head) {
+		return
+	}
+	if !s.Rules.WrapEdges {
+		s.Dead = true
+		return
+	}
+	for i, p := range s.Tail {
+		if p == s.Head {
+			for _, removed := range s.Tail[i:] {
+				s.Board.Unmark(removed)
+			}
+			s.Tail = s.Tail[:i]
+			return
+		}
+	}
+}
@@ -1,223 +1,377 @@
 package main
 
 import (
-	"github.com/faiface/pixel"
-	"github.com/faiface/pixel/pixelgl"
-	"golang.org/x/image/colornames"
-	"github.com/faiface/pixel/imdraw"
-	"time"
+	"flag"
+	"fmt"
+	"image"
 	"math/rand"
+	"time"
+
+	"github.com/AnatolyRugalev/go-snake/ai"
+	"github.com/AnatolyRugalev/go-snake/game"
+	"github.com/AnatolyRugalev/go-snake/render"
+	"github.com/AnatolyRugalev/go-snake/render/gl"
+	"github.com/AnatolyRugalev/go-snake/render/headless"
+	"github.com/AnatolyRugalev/go-snake/render/tui"
+	"github.com/AnatolyRugalev/go-snake/replay"
+	"github.com/AnatolyRugalev/go-snake/stats"
+	"github.com/faiface/pixel/pixelgl"
 )
 
-const polySize = 50
-const size = 10
+var (
+	trainFlag       = flag.Bool("train", false, "evolve an autopilot instead of playing")
+	generationsFlag = flag.Int("generations", 100, "number of generations to evolve (with -train)")
+	popFlag         = flag.Int("pop", 50, "population size (with -train)")
+	saveFlag        = flag.String("save", "genome.json", "path to save the best genome to (with -train)")
+	playFlag        = flag.String("play", "", "path to a genome.json to play with the autopilot")
+	sizeFlag        = flag.Int64("size", 10, "board width and height, in cells")
+	recordFlag      = flag.String("record", "", "path to record this game's input to (out.snk)")
+	replayFlag      = flag.String("replay", "", "path to a recording to replay (in.snk)")
+	backendFlag     = flag.String("backend", "gl", "rendering backend: gl, tui, or headless")
+	maxTicksFlag    = flag.Int64("max-ticks", 0, "stop after this many ticks (headless backend only; 0 = unlimited)")
+	modeFlag        = flag.String("mode", "wrap", "game mode: wrap, classic, or maze")
+	levelFlag       = flag.String("level", "", "path to a maze level file (with -mode=maze)")
+)
 
-func convertCords(x int64, y int64) (float64, float64) {
-	return float64((x - 1) * polySize), float64((size - y) * polySize)
+func drawSnake(r render.Renderer, s *game.Snake) {
+	for _, o := range s.Rules.Obstacles {
+		r.DrawCell(o.X, o.Y, render.CellWall)
+	}
+	for _, food := range s.FoodPoints {
+		r.DrawCell(food.X, food.Y, render.CellFood)
+	}
+	for _, point := range s.Tail {
+		r.DrawCell(point.X, point.Y, render.CellBody)
+	}
+	r.DrawCell(s.Head.X, s.Head.Y, render.CellHead)
 }
 
-func drawSquare(imd *imdraw.IMDraw, x int64, y int64, color pixel.RGBA) {
-	imd.Color = color
-	realX, realY := convertCords(x, y)
-	imd.Push(
-		pixel.V(realX, realY),
-		pixel.V(realX+polySize, realY),
-		pixel.V(realX+polySize, realY+polySize),
-		pixel.V(realX, realY+polySize),
-	)
-	imd.Polygon(0)
-}
+// autopilot is the network driving the snake in -play mode. It stays nil
+// for normal keyboard-controlled games.
+var autopilot *ai.Network
 
-func drawGrid(imd *imdraw.IMDraw) {
-	top := float64(size * polySize)
-	right := float64(size * polySize)
-	left := float64(0)
-	bottom := float64(0)
-	for x := left; x < right; x += polySize {
-		imd.Color = pixel.RGB(0, 0, 0)
-		imd.Push(
-			pixel.V(x, top),
-			pixel.V(x, bottom),
-		)
-		imd.Line(1)
-	}
-	for y := bottom; y < top; y += polySize {
-		imd.Color = pixel.RGB(0, 0, 0)
-		imd.Push(
-			pixel.V(left, y),
-			pixel.V(right, y),
-		)
-		imd.Line(1)
+// snakeInputs builds the network input vector for the live game: relative
+// food vector, distance to each wall, and a danger flag for each of the
+// four adjacent cells. This mirrors the headless simulation in ai.Simulate.
+func snakeInputs() [ai.InputSize]float64 {
+	var in [ai.InputSize]float64
+	if len(snake.FoodPoints) > 0 {
+		in[0] = float64(snake.FoodPoints[0].X - snake.Head.X)
+		in[1] = float64(snake.FoodPoints[0].Y - snake.Head.Y)
 	}
+	in[2] = float64(snake.Head.X)
+	in[3] = float64(boardWidth - snake.Head.X)
+	in[4] = float64(snake.Head.Y)
+	in[5] = float64(boardHeight - snake.Head.Y)
+	deltas := [4]game.Point{{X: 0, Y: -1}, {X: 0, Y: 1}, {X: -1, Y: 0}, {X: 1, Y: 0}}
+	for i, d := range deltas {
+		next := game.Point{X: snake.Head.X + d.X, Y: snake.Head.Y + d.Y}
+		for _, t := range snake.Tail {
+			if t == next {
+				in[6+i] = 1
+				break
+			}
+		}
+	}
+	return in
 }
 
-type Point struct {
-	x int64
-	y int64
-}
-
-type Snake struct {
-	nextPoint Point
-	growth int64
-	direction byte
-	nextDirection byte
-	head      Point
-	tail      []Point
+// autopilotDirection asks the loaded network for the next move.
+func autopilotDirection() render.Direction {
+	dirs := [4]render.Direction{render.DirUp, render.DirDown, render.DirLeft, render.DirRight}
+	return dirs[autopilot.Decide(snakeInputs())]
 }
 
-func (s *Snake) draw(imd *imdraw.IMDraw) {
-	drawSquare(imd, s.nextPoint.x, s.nextPoint.y, pixel.RGB(0, 1, 0))
-	for _, point := range s.tail {
-		drawSquare(imd, point.x, point.y, pixel.RGB(0, 0, 1))
+// applyDirection turns a render.Direction input into snake.NextDirection,
+// refusing to reverse straight into the snake's own neck.
+func applyDirection(dir render.Direction) {
+	switch dir {
+	case render.DirLeft:
+		if snake.Direction != 'r' {
+			snake.NextDirection = 'l'
+		}
+	case render.DirRight:
+		if snake.Direction != 'l' {
+			snake.NextDirection = 'r'
+		}
+	case render.DirUp:
+		if snake.Direction != 'd' {
+			snake.NextDirection = 'u'
+		}
+	case render.DirDown:
+		if snake.Direction != 'u' {
+			snake.NextDirection = 'd'
+		}
 	}
-	drawSquare(imd, s.head.x, s.head.y, pixel.RGB(1, 0, 0))
 }
 
-func (s *Snake) move() {
-	// If we have to grow, do not remove last point of tail
-	delta := 1
-	if s.growth > 0 {
-		delta = 0
-		s.growth--
-	}
-	// Remove last point of tail and add new point where the head is pointing before movement
-	s.tail = append([]Point{s.head}, s.tail[:len(s.tail)-delta]...)
-	s.direction = s.nextDirection
-	// Move head
-	var dX, dY int64 = 0, 0
-	switch s.direction {
-	case 'u':
-		dY = -1
-		break
-	case 'd':
-		dY = +1
-		break
-	case 'l':
-		dX = -1
-		break
-	case 'r':
-		dX = 1
-		break
-	}
-	s.head.y += dY
-	s.head.x += dX
-	if s.head.y > 10 {
-		s.head.y = 1
-	}
-	if s.head.y < 1 {
-		s.head.y = 10
-	}
-	if s.head.x > 10 {
-		s.head.x = 1
-	}
-	if s.head.x < 1 {
-		s.head.x = 10
-	}
-}
+// boardWidth and boardHeight are the board dimensions in cells: equal to
+// -size for wrap/classic modes, or the level's dimensions for maze mode.
+var boardWidth, boardHeight int64
 
-func (s *Snake) generateNextPoint() {
-	s.nextPoint = Point{
-		rand.Int63n(size - 1) + 1,
-		rand.Int63n(size - 1) + 1,
-	}
-	if s.nextPoint == s.head {
-		s.generateNextPoint()
+var snake *game.Snake
+
+// Session stats: sessionHistory accumulates this run's samples, lifetimeHistory
+// holds everything loaded from disk so the overlay can plot current vs.
+// lifetime best. The overlay is re-rendered only every few ticks since
+// go-chart rendering is too slow to do every frame.
+var (
+	sessionHistory  = &stats.History{}
+	lifetimeHistory = &stats.History{}
+	overlayVisible  bool
+	overlayImage    image.Image
+	tick            int64
+	applesEaten     int64
+	deaths          int64
+)
+
+// recording captures direction changes for -record; replayPlayer drives
+// them back for -replay. At most one of the two is set.
+var (
+	recording    *replay.Recording
+	replayPlayer *replay.Player
+)
+
+func loadLifetimeHistory() *stats.History {
+	path, err := stats.DefaultPath()
+	if err != nil {
+		fmt.Println("stats: could not resolve history path:", err)
+		return &stats.History{}
 	}
-	for _, point := range s.tail {
-		if point == s.nextPoint {
-			s.generateNextPoint()
-		}
+	h, err := stats.LoadHistory(path)
+	if err != nil {
+		fmt.Println("stats: could not load history:", err)
+		return &stats.History{}
 	}
+	return h
 }
 
-func (s *Snake) checkPoint() {
-	if s.head == s.nextPoint {
-		s.growth++
-		s.generateNextPoint()
+func saveHistory() {
+	path, err := stats.DefaultPath()
+	if err != nil {
+		return
+	}
+	merged := &stats.History{Records: append(append([]stats.Record{}, lifetimeHistory.Records...), sessionHistory.Records...)}
+	if err := merged.Save(path); err != nil {
+		fmt.Println("stats: could not save history:", err)
 	}
 }
 
-func (s *Snake) checkCollisions() {
-	for i, point := range s.tail {
-		if point == s.head {
-			// Collision detected
-			s.tail = s.tail[0:i]
-			break
-		}
+func refreshOverlay() {
+	img, err := stats.RenderOverlay(sessionHistory, lifetimeHistory)
+	if err != nil {
+		fmt.Println("stats: could not render overlay:", err)
+		return
 	}
+	overlayImage = img
 }
 
-var snake Snake = Snake{
-	head: Point{3, 3},
-	growth: 0,
-	tail: []Point{
-		{3,4},
-		{3,5},
-		{3,6},
-		{3,7},
-		{3,8},
-	},
-	direction: 'u',
-	nextDirection: 'u',
+// GameConfig describes the board the game loop is driving. It's kept
+// separate from the Renderer so the same loop can run headless, in a
+// terminal, or in the pixelgl window.
+type GameConfig struct {
+	Width  int64
+	Height int64
 }
 
-func run() {
-	cfg := pixelgl.WindowConfig{
-		Title:  "Snake",
-		Bounds: pixel.R(0, 0, polySize*size, polySize*size),
-		VSync:  true,
-	}
-	win, err := pixelgl.NewWindow(cfg)
-	if err != nil {
-		panic(err)
-	}
+// Run drives the game loop against r until it reports Closed or the snake
+// dies.
+func Run(r render.Renderer, cfg GameConfig) {
+	lifetimeHistory = loadLifetimeHistory()
+	overlayCap, supportsOverlay := r.(render.Overlay)
+	_, untimed := r.(render.Untimed)
 
-	drawn := false
 	last := time.Now()
-	frequency := float64(0.25)
-	snake.generateNextPoint()
-	for !win.Closed() {
-		win.Clear(colornames.Aliceblue)
-		imd := imdraw.New(nil)
-		if !drawn {
-			draw(imd)
-			imd.Draw(win)
-			win.Update()
-			drawn = true
+	frequency := 0.25
+	snake.GenerateFood()
+	for !r.Closed() && !snake.Dead {
+		if supportsOverlay && overlayCap.ToggleOverlayPressed() {
+			overlayVisible = !overlayVisible
+			if overlayVisible {
+				refreshOverlay()
+			}
 		}
 
-		if win.Pressed(pixelgl.KeyLeft) && snake.direction != 'r' {
-			snake.nextDirection = 'l'
-		}
-		if win.Pressed(pixelgl.KeyRight) && snake.direction != 'l' {
-			snake.nextDirection = 'r'
-		}
-		if win.Pressed(pixelgl.KeyUp) && snake.direction != 'd' {
-			snake.nextDirection = 'u'
-		}
-		if win.Pressed(pixelgl.KeyDown) && snake.direction != 'u' {
-			snake.nextDirection = 'd'
+		dir := r.PollInput()
+		if replayPlayer != nil {
+			if d, ok := replayPlayer.DirectionAt(tick + 1); ok {
+				snake.NextDirection = d
+			}
+		} else if autopilot != nil {
+			applyDirection(autopilotDirection())
+		} else {
+			prevDirection := snake.NextDirection
+			applyDirection(dir)
+			if recording != nil && snake.NextDirection != prevDirection {
+				recording.RecordDirection(tick+1, snake.NextDirection)
+			}
 		}
 
 		dt := time.Since(last).Seconds()
-		if dt > frequency {
+		if untimed || dt > frequency {
 			last = time.Now()
-			snake.move()
-			snake.checkPoint()
-			snake.checkCollisions()
+			snake.Move()
+			prevGrowth := snake.Growth
+			snake.CheckPoint()
+			if snake.Growth > prevGrowth {
+				applesEaten++
+			}
+			preCollisionTail := len(snake.Tail)
+			snake.CheckCollisions()
+			if snake.Dead || len(snake.Tail) < preCollisionTail {
+				deaths++
+			}
+			tick++
+			sessionHistory.Record(tick, int64(len(snake.Tail))+1, applesEaten, deaths)
+			if overlayVisible && tick%20 == 0 {
+				refreshOverlay()
+			}
+		}
+
+		r.BeginFrame()
+		drawSnake(r, snake)
+		if supportsOverlay {
+			overlayCap.ShowOverlay(overlayImage, overlayVisible)
 		}
-		draw(imd)
+		r.EndFrame()
+	}
+
+	saveHistory()
+	if recording != nil {
+		if err := replay.Save(*recordFlag, recording); err != nil {
+			fmt.Println("replay: could not save recording:", err)
+		}
+	}
+}
 
-		imd.Draw(win)
-		win.Update()
+// train evolves an autopilot network, saving the best genome seen so far
+// after every generation so a run can be resumed from disk.
+func train() {
+	cfg := ai.DefaultEvolveConfig()
+	cfg.Population = *popFlag
+	cfg.Generations = *generationsFlag
+	cfg.OnGeneration = func(generation int, best ai.Genome, bestFitness float64) {
+		fmt.Printf("generation %d: best fitness %.2f\n", generation, bestFitness)
+		if err := ai.SaveGenome(*saveFlag, best); err != nil {
+			fmt.Println("failed to save genome:", err)
+		}
+	}
+	best, bestFitness := ai.Evolve(cfg)
+	fmt.Printf("training complete: best fitness %.2f\n", bestFitness)
+	if err := ai.SaveGenome(*saveFlag, best); err != nil {
+		fmt.Println("failed to save genome:", err)
 	}
 }
 
-func draw(imd *imdraw.IMDraw) {
-	snake.draw(imd)
-	drawGrid(imd)
+// requireMinBoardSize panics if any point of the starting body (head plus
+// tail) would fall outside a boardSize x boardSize board. Board.Mark
+// silently drops points outside the quadtree's boundary (Quadtree.Insert
+// returns false), so an undersized board would otherwise leave body
+// segments unmarked: food could spawn on top of them and self-collision
+// checks would miss them entirely.
+func requireMinBoardSize(mode string, boardSize int64, start game.Point, tail []game.Point) {
+	required := start.X
+	if start.Y > required {
+		required = start.Y
+	}
+	for _, p := range tail {
+		if p.X > required {
+			required = p.X
+		}
+		if p.Y > required {
+			required = p.Y
+		}
+	}
+	required++
+	if boardSize < required {
+		panic(fmt.Sprintf("-size %d is too small for -mode=%s's starting body; need at least %d", boardSize, mode, required))
+	}
 }
 
 func main() {
-	pixelgl.Run(run)
+	flag.Parse()
+	if *trainFlag {
+		train()
+		return
+	}
+
+	seed := time.Now().UnixNano()
+	boardWidth, boardHeight = *sizeFlag, *sizeFlag
+
+	var rules game.Rules
+	start := game.Point{X: 3, Y: 3}
+	tail := []game.Point{
+		{X: start.X, Y: start.Y + 1},
+		{X: start.X, Y: start.Y + 2},
+		{X: start.X, Y: start.Y + 3},
+		{X: start.X, Y: start.Y + 4},
+		{X: start.X, Y: start.Y + 5},
+	}
+	switch *modeFlag {
+	case "classic":
+		requireMinBoardSize(*modeFlag, *sizeFlag, start, tail)
+		rules = game.ClassicRules(boardWidth, boardHeight)
+	case "maze":
+		if *levelFlag == "" {
+			panic("-mode=maze requires -level")
+		}
+		var err error
+		rules, start, err = game.LoadLevel(*levelFlag)
+		if err != nil {
+			panic(err)
+		}
+		boardWidth, boardHeight = rules.Width, rules.Height
+		tail = nil
+	default:
+		requireMinBoardSize(*modeFlag, *sizeFlag, start, tail)
+		rules = game.WrapRules(boardWidth, boardHeight)
+	}
+
+	if *replayFlag != "" {
+		rec, err := replay.Load(*replayFlag)
+		if err != nil {
+			panic(err)
+		}
+		seed = rec.Seed
+		boardWidth, boardHeight = rec.BoardSize, rec.BoardSize
+		rules.Width, rules.Height = boardWidth, boardHeight
+		replayPlayer = replay.NewPlayer(rec)
+	}
+	if *recordFlag != "" {
+		recording = replay.NewRecording(seed, boardWidth)
+	}
+
+	board := game.NewBoard(boardWidth, boardHeight)
+	rng := rand.New(rand.NewSource(seed))
+	snake = game.NewSnake(board, rules, start, tail, rng)
+
+	if *playFlag != "" {
+		g, err := ai.LoadGenome(*playFlag)
+		if err != nil {
+			panic(err)
+		}
+		net := ai.NewNetwork(g)
+		autopilot = &net
+	}
+
+	cfg := GameConfig{Width: boardWidth, Height: boardHeight}
+	switch *backendFlag {
+	case "tui":
+		r, err := tui.New(boardWidth, boardHeight)
+		if err != nil {
+			panic(err)
+		}
+		defer r.Close()
+		Run(r, cfg)
+	case "headless":
+		Run(headless.New(*maxTicksFlag), cfg)
+	default:
+		pixelgl.Run(func() {
+			r, err := gl.New(boardWidth, boardHeight)
+			if err != nil {
+				panic(err)
+			}
+			Run(r, cfg)
+		})
+	}
 }
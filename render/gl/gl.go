@@ -0,0 +1,132 @@
+// Package gl renders the game in a pixelgl window. It's the original
+// rendering code from main.go, extracted behind the render.Renderer
+// interface.
+package gl
+
+import (
+	"image"
+
+	"github.com/AnatolyRugalev/go-snake/render"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/pixelgl"
+	"golang.org/x/image/colornames"
+)
+
+const polySize = 50
+
+// Renderer draws the board in a pixelgl window and can show a go-chart
+// stats overlay on top of it.
+type Renderer struct {
+	win    *pixelgl.Window
+	imd    *imdraw.IMDraw
+	width  int64
+	height int64
+
+	overlaySprite *pixel.Sprite
+	overlayImg    image.Image
+}
+
+// New opens a pixelgl window sized for a width x height board. It must be
+// called from pixelgl.Run's callback, like the game always has.
+func New(width, height int64) (*Renderer, error) {
+	cfg := pixelgl.WindowConfig{
+		Title:  "Snake",
+		Bounds: pixel.R(0, 0, polySize*float64(width), polySize*float64(height)),
+		VSync:  true,
+	}
+	win, err := pixelgl.NewWindow(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{win: win, width: width, height: height}, nil
+}
+
+func (r *Renderer) convertCoords(x, y int64) (float64, float64) {
+	return float64(x * polySize), float64((r.height - 1 - y) * polySize)
+}
+
+func (r *Renderer) BeginFrame() {
+	r.win.Clear(colornames.Aliceblue)
+	r.imd = imdraw.New(nil)
+	r.drawGrid()
+}
+
+func (r *Renderer) drawGrid() {
+	top := float64(r.height * polySize)
+	right := float64(r.width * polySize)
+	for x := float64(0); x < right; x += polySize {
+		r.imd.Color = pixel.RGB(0, 0, 0)
+		r.imd.Push(pixel.V(x, top), pixel.V(x, 0))
+		r.imd.Line(1)
+	}
+	for y := float64(0); y < top; y += polySize {
+		r.imd.Color = pixel.RGB(0, 0, 0)
+		r.imd.Push(pixel.V(0, y), pixel.V(right, y))
+		r.imd.Line(1)
+	}
+}
+
+func (r *Renderer) DrawCell(x, y int64, kind render.CellKind) {
+	color := pixel.RGB(0, 0, 1)
+	switch kind {
+	case render.CellHead:
+		color = pixel.RGB(1, 0, 0)
+	case render.CellFood:
+		color = pixel.RGB(0, 1, 0)
+	case render.CellWall:
+		color = pixel.RGB(0.2, 0.2, 0.2)
+	}
+	r.imd.Color = color
+	realX, realY := r.convertCoords(x, y)
+	r.imd.Push(
+		pixel.V(realX, realY),
+		pixel.V(realX+polySize, realY),
+		pixel.V(realX+polySize, realY+polySize),
+		pixel.V(realX, realY+polySize),
+	)
+	r.imd.Polygon(0)
+}
+
+func (r *Renderer) EndFrame() {
+	r.imd.Draw(r.win)
+	if r.overlaySprite != nil {
+		r.overlaySprite.Draw(r.win, pixel.IM.Moved(r.win.Bounds().Center()))
+	}
+	r.win.Update()
+}
+
+func (r *Renderer) PollInput() render.Direction {
+	switch {
+	case r.win.Pressed(pixelgl.KeyLeft):
+		return render.DirLeft
+	case r.win.Pressed(pixelgl.KeyRight):
+		return render.DirRight
+	case r.win.Pressed(pixelgl.KeyUp):
+		return render.DirUp
+	case r.win.Pressed(pixelgl.KeyDown):
+		return render.DirDown
+	}
+	return render.DirNone
+}
+
+func (r *Renderer) Closed() bool {
+	return r.win.Closed()
+}
+
+func (r *Renderer) ToggleOverlayPressed() bool {
+	return r.win.JustPressed(pixelgl.KeyP)
+}
+
+func (r *Renderer) ShowOverlay(img image.Image, visible bool) {
+	if !visible {
+		r.overlaySprite = nil
+		return
+	}
+	if img == r.overlayImg && r.overlaySprite != nil {
+		return
+	}
+	r.overlayImg = img
+	pic := pixel.PictureDataFromImage(img)
+	r.overlaySprite = pixel.NewSprite(pic, pic.Bounds())
+}
@@ -0,0 +1,35 @@
+// Package headless provides a no-op render.Renderer that drives the game
+// loop without drawing anything (-backend=headless). AI training does not
+// use it: ai.Simulate runs its own simulation.
+package headless
+
+import "github.com/AnatolyRugalev/go-snake/render"
+
+// Renderer draws nothing and never asks to stop on its own; callers that
+// need a bounded run should set MaxTicks. It also reports Untimed so the
+// game loop advances a tick every iteration instead of waiting for the
+// game's move frequency, since there's nothing here for a human to watch
+// at a human-visible rate.
+type Renderer struct {
+	// MaxTicks stops the game after this many ticks if set (0 means run
+	// until something else, e.g. the game logic, decides to stop).
+	MaxTicks int64
+
+	frames int64
+}
+
+// New returns a headless renderer that stops after maxTicks ticks, or
+// runs forever if maxTicks is 0.
+func New(maxTicks int64) *Renderer {
+	return &Renderer{MaxTicks: maxTicks}
+}
+
+func (r *Renderer) BeginFrame()                               { r.frames++ }
+func (r *Renderer) DrawCell(x, y int64, kind render.CellKind) {}
+func (r *Renderer) EndFrame()                                 {}
+func (r *Renderer) PollInput() render.Direction               { return render.DirNone }
+func (r *Renderer) Untimed() bool                             { return true }
+
+func (r *Renderer) Closed() bool {
+	return r.MaxTicks > 0 && r.frames >= r.MaxTicks
+}
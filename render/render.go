@@ -0,0 +1,60 @@
+// Package render defines the Renderer interface the game loop draws
+// through, so the same loop can run against a pixelgl window, a terminal,
+// or nothing at all.
+package render
+
+import "image"
+
+// CellKind is what occupies a board cell being drawn.
+type CellKind int
+
+const (
+	CellEmpty CellKind = iota
+	CellHead
+	CellBody
+	CellFood
+	CellWall
+)
+
+// Direction is the move a player (or AI) asked for. DirNone means no new
+// direction was requested this frame.
+type Direction int
+
+const (
+	DirNone Direction = iota
+	DirUp
+	DirDown
+	DirLeft
+	DirRight
+)
+
+// Renderer draws one board frame at a time and reports input. BeginFrame
+// and EndFrame bracket a frame; DrawCell is called once per occupied cell
+// in between.
+type Renderer interface {
+	BeginFrame()
+	DrawCell(x, y int64, kind CellKind)
+	EndFrame()
+	PollInput() Direction
+	// Closed reports whether the renderer wants the game to stop.
+	Closed() bool
+}
+
+// Overlay is implemented by renderers that can show a side-channel image
+// (the stats chart, see the stats package) on top of the board. Not every
+// backend supports it.
+type Overlay interface {
+	// ToggleOverlayPressed reports, once per press, whether the player
+	// asked to toggle the overlay.
+	ToggleOverlayPressed() bool
+	ShowOverlay(img image.Image, visible bool)
+}
+
+// Untimed is implemented by renderers that don't draw at a human-visible
+// rate and so want the game loop to advance a tick every iteration
+// instead of being throttled to the game's move frequency — e.g. a
+// headless renderer running training or a fixed number of ticks as fast
+// as possible.
+type Untimed interface {
+	Untimed() bool
+}
@@ -0,0 +1,179 @@
+// Package tui renders the game in a terminal using box-drawing runes,
+// reading the keyboard in raw mode via golang.org/x/term.
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AnatolyRugalev/go-snake/render"
+	"golang.org/x/term"
+)
+
+const (
+	runeEmpty = ' '
+	runeHead  = '█'
+	runeBody  = '░'
+	runeFood  = '●'
+	runeWall  = '▓'
+)
+
+// Renderer draws the board as text and reads WASD/arrow keys from a raw
+// terminal.
+type Renderer struct {
+	width  int64
+	height int64
+	grid   [][]rune
+	out    *bufio.Writer
+
+	oldState *term.State
+	input    chan byte
+	quit     bool
+}
+
+// New puts the terminal into raw mode and starts reading keys in the
+// background.
+func New(width, height int64) (*Renderer, error) {
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	r := &Renderer{
+		width:    width,
+		height:   height,
+		out:      bufio.NewWriter(os.Stdout),
+		oldState: oldState,
+		input:    make(chan byte, 16),
+	}
+	r.grid = make([][]rune, height)
+	for y := range r.grid {
+		r.grid[y] = make([]rune, width)
+	}
+	go r.readKeys()
+	return r, nil
+}
+
+func (r *Renderer) readKeys() {
+	buf := make([]byte, 1)
+	for {
+		if _, err := os.Stdin.Read(buf); err != nil {
+			r.input <- 'q'
+			return
+		}
+		r.input <- buf[0]
+	}
+}
+
+// Close restores the terminal to its previous state.
+func (r *Renderer) Close() error {
+	return term.Restore(int(os.Stdin.Fd()), r.oldState)
+}
+
+func (r *Renderer) BeginFrame() {
+	for y := range r.grid {
+		for x := range r.grid[y] {
+			r.grid[y][x] = runeEmpty
+		}
+	}
+}
+
+func (r *Renderer) DrawCell(x, y int64, kind render.CellKind) {
+	if x < 0 || x >= r.width || y < 0 || y >= r.height {
+		return
+	}
+	switch kind {
+	case render.CellHead:
+		r.grid[y][x] = runeHead
+	case render.CellBody:
+		r.grid[y][x] = runeBody
+	case render.CellFood:
+		r.grid[y][x] = runeFood
+	case render.CellWall:
+		r.grid[y][x] = runeWall
+	}
+}
+
+func (r *Renderer) EndFrame() {
+	r.out.WriteString("\x1b[H\x1b[2J")
+	r.out.WriteString("┌")
+	for x := int64(0); x < r.width; x++ {
+		r.out.WriteString("─")
+	}
+	r.out.WriteString("┐\r\n")
+	for _, row := range r.grid {
+		r.out.WriteString("│")
+		for _, c := range row {
+			r.out.WriteRune(c)
+		}
+		r.out.WriteString("│\r\n")
+	}
+	r.out.WriteString("└")
+	for x := int64(0); x < r.width; x++ {
+		r.out.WriteString("─")
+	}
+	r.out.WriteString("┘\r\n")
+	fmt.Fprint(r.out, "q to quit\r\n")
+	r.out.Flush()
+}
+
+// PollInput drains whatever keys arrived since the last call and returns
+// the last direction requested, if any. Arrow keys arrive as a 3-byte
+// escape sequence (ESC [ A/B/C/D); WASD is accepted too.
+func (r *Renderer) PollInput() render.Direction {
+	dir := render.DirNone
+	for {
+		select {
+		case b := <-r.input:
+			switch b {
+			case 'q':
+				r.quit = true
+			case 'w', 'W':
+				dir = render.DirUp
+			case 's', 'S':
+				dir = render.DirDown
+			case 'a', 'A':
+				dir = render.DirLeft
+			case 'd', 'D':
+				dir = render.DirRight
+			case 0x1b:
+				if d, ok := r.readArrow(); ok {
+					dir = d
+				}
+			}
+		default:
+			return dir
+		}
+	}
+}
+
+func (r *Renderer) readArrow() (render.Direction, bool) {
+	var seq [2]byte
+	for i := range seq {
+		select {
+		case b := <-r.input:
+			seq[i] = b
+		case <-time.After(10 * time.Millisecond):
+			return render.DirNone, false
+		}
+	}
+	if seq[0] != '[' {
+		return render.DirNone, false
+	}
+	switch seq[1] {
+	case 'A':
+		return render.DirUp, true
+	case 'B':
+		return render.DirDown, true
+	case 'C':
+		return render.DirRight, true
+	case 'D':
+		return render.DirLeft, true
+	}
+	return render.DirNone, false
+}
+
+func (r *Renderer) Closed() bool {
+	return r.quit
+}
@@ -0,0 +1,86 @@
+// Package replay records a game's RNG seed, board size and the ticks at
+// which the player changed direction, so the exact same game can be
+// re-simulated later.
+package replay
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Event is a direction change that happened at a given tick.
+type Event struct {
+	Tick      int64 `json:"tick"`
+	Direction byte  `json:"direction"`
+}
+
+// Recording holds what a replay re-simulates a game from: the seed the
+// snake's RNG was created with, a single board size used for both width
+// and height, and the sequence of direction changes. The game mode and
+// level are not part of it.
+type Recording struct {
+	Seed      int64   `json:"seed"`
+	BoardSize int64   `json:"board_size"`
+	Events    []Event `json:"events"`
+}
+
+// NewRecording starts an empty recording for a game seeded with seed on a
+// boardSize x boardSize board.
+func NewRecording(seed, boardSize int64) *Recording {
+	return &Recording{Seed: seed, BoardSize: boardSize}
+}
+
+// RecordDirection appends a direction change at the given tick.
+func (r *Recording) RecordDirection(tick int64, direction byte) {
+	r.Events = append(r.Events, Event{Tick: tick, Direction: direction})
+}
+
+// Save writes the recording to path.
+func Save(path string, r *Recording) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Load reads a recording previously written by Save.
+func Load(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Recording
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Player replays a Recording's direction changes tick by tick.
+type Player struct {
+	events []Event
+	next   int
+}
+
+// NewPlayer returns a Player that walks r's events in order.
+func NewPlayer(r *Recording) *Player {
+	return &Player{events: r.Events}
+}
+
+// DirectionAt returns the last direction recorded for tick (or for any
+// earlier tick not yet played back), if any. Callers should keep the
+// snake's current direction when ok is false.
+func (p *Player) DirectionAt(tick int64) (direction byte, ok bool) {
+	for p.next < len(p.events) && p.events[p.next].Tick <= tick {
+		direction = p.events[p.next].Direction
+		p.next++
+		ok = true
+	}
+	return direction, ok
+}
+
+// Done reports whether every recorded event has been played back.
+func (p *Player) Done() bool {
+	return p.next >= len(p.events)
+}
@@ -0,0 +1,135 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+
+	chart "github.com/wcharczuk/go-chart/v2"
+)
+
+// RenderOverlay renders the current session's length-over-time (against
+// the lifetime best, if any) stacked above a histogram of apple-to-apple
+// intervals, as a single RGBA image suitable for a pixelgl sprite.
+func RenderOverlay(current, lifetime *History) (*image.RGBA, error) {
+	lengthImg, err := renderLengthChart(current, lifetime)
+	if err != nil {
+		return nil, err
+	}
+	histImg, err := renderIntervalHistogram(current)
+	if err != nil {
+		return nil, err
+	}
+
+	width := lengthImg.Bounds().Dx()
+	if w := histImg.Bounds().Dx(); w > width {
+		width = w
+	}
+	height := lengthImg.Bounds().Dy() + histImg.Bounds().Dy()
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(out, lengthImg.Bounds(), lengthImg, image.Point{}, draw.Src)
+	offset := image.Pt(0, lengthImg.Bounds().Dy())
+	draw.Draw(out, histImg.Bounds().Add(offset), histImg, image.Point{}, draw.Src)
+	return out, nil
+}
+
+func renderLengthChart(current, lifetime *History) (image.Image, error) {
+	series := []chart.Series{
+		chart.ContinuousSeries{
+			Name:    "current",
+			XValues: ticksOf(current),
+			YValues: lengthsOf(current),
+		},
+	}
+	if lifetime != nil && len(lifetime.Records) > 0 {
+		best := float64(lifetime.BestLength())
+		xs := ticksOf(current)
+		ys := make([]float64, len(xs))
+		for i := range ys {
+			ys[i] = best
+		}
+		series = append(series, chart.ContinuousSeries{
+			Name:    "lifetime best",
+			XValues: xs,
+			YValues: ys,
+		})
+	}
+	c := chart.Chart{
+		Title:  "Length over time",
+		Width:  400,
+		Height: 200,
+		Series: series,
+	}
+	return renderPNG(c)
+}
+
+func renderIntervalHistogram(current *History) (image.Image, error) {
+	const buckets = 10
+	counts := bucketize(current.AppleIntervals(), buckets)
+	bars := make([]chart.Value, buckets)
+	for i, count := range counts {
+		bars[i] = chart.Value{Label: fmt.Sprintf("%d", i), Value: float64(count)}
+	}
+	bc := chart.BarChart{
+		Title:  "Apple-to-apple intervals",
+		Width:  400,
+		Height: 200,
+		Bars:   bars,
+	}
+	return renderPNG(bc)
+}
+
+// renderer is implemented by both chart.Chart and chart.BarChart.
+type renderer interface {
+	Render(rp chart.RendererProvider, w io.Writer) error
+}
+
+func renderPNG(r renderer) (image.Image, error) {
+	buf := &bytes.Buffer{}
+	if err := r.Render(chart.PNG, buf); err != nil {
+		return nil, err
+	}
+	return png.Decode(buf)
+}
+
+func ticksOf(h *History) []float64 {
+	out := make([]float64, len(h.Records))
+	for i, r := range h.Records {
+		out[i] = float64(r.Tick)
+	}
+	return out
+}
+
+func lengthsOf(h *History) []float64 {
+	out := make([]float64, len(h.Records))
+	for i, r := range h.Records {
+		out[i] = float64(r.Length)
+	}
+	return out
+}
+
+// bucketize spreads values into `buckets` equal-width bins over
+// [0, max(values)] and returns each bin's count.
+func bucketize(values []int64, buckets int) []int64 {
+	counts := make([]int64, buckets)
+	if len(values) == 0 {
+		return counts
+	}
+	var max int64
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	for _, v := range values {
+		idx := int(v * int64(buckets) / (max + 1))
+		if idx >= buckets {
+			idx = buckets - 1
+		}
+		counts[idx]++
+	}
+	return counts
+}
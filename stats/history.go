@@ -0,0 +1,126 @@
+// Package stats records per-tick game statistics and renders them as a
+// chart overlay, so a session can be compared against its own lifetime
+// best.
+package stats
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Record is one sample of game state at a given tick.
+type Record struct {
+	Tick        int64
+	Length      int64
+	ApplesEaten int64
+	Deaths      int64
+}
+
+// History is an ordered list of Records, either the current session or one
+// loaded from a previous session's CSV file.
+type History struct {
+	Records []Record
+}
+
+// Record appends a sample to the history.
+func (h *History) Record(tick, length, applesEaten, deaths int64) {
+	h.Records = append(h.Records, Record{Tick: tick, Length: length, ApplesEaten: applesEaten, Deaths: deaths})
+}
+
+// BestLength returns the highest length ever recorded, or 0 if h is empty.
+func (h *History) BestLength() int64 {
+	var best int64
+	for _, r := range h.Records {
+		if r.Length > best {
+			best = r.Length
+		}
+	}
+	return best
+}
+
+// AppleIntervals returns the number of ticks between each apple eaten and
+// the one before it.
+func (h *History) AppleIntervals() []int64 {
+	var intervals []int64
+	lastTick := int64(-1)
+	lastApples := int64(0)
+	for _, r := range h.Records {
+		if r.ApplesEaten > lastApples {
+			if lastTick >= 0 {
+				intervals = append(intervals, r.Tick-lastTick)
+			}
+			lastTick = r.Tick
+			lastApples = r.ApplesEaten
+		}
+	}
+	return intervals
+}
+
+// DefaultPath returns ~/.go-snake/history.csv.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".go-snake", "history.csv"), nil
+}
+
+// LoadHistory reads a CSV file previously written by Save. A missing file
+// is not an error; it returns an empty History.
+func LoadHistory(path string) (*History, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	h := &History{}
+	for _, row := range rows {
+		if len(row) != 4 {
+			continue
+		}
+		tick, _ := strconv.ParseInt(row[0], 10, 64)
+		length, _ := strconv.ParseInt(row[1], 10, 64)
+		apples, _ := strconv.ParseInt(row[2], 10, 64)
+		deaths, _ := strconv.ParseInt(row[3], 10, 64)
+		h.Records = append(h.Records, Record{tick, length, apples, deaths})
+	}
+	return h, nil
+}
+
+// Save writes the history to path as CSV, creating parent directories as
+// needed.
+func (h *History) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	for _, r := range h.Records {
+		row := []string{
+			strconv.FormatInt(r.Tick, 10),
+			strconv.FormatInt(r.Length, 10),
+			strconv.FormatInt(r.ApplesEaten, 10),
+			strconv.FormatInt(r.Deaths, 10),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}